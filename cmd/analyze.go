@@ -0,0 +1,105 @@
+/*
+Copyright 2023 The K8sGPT Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/analysis"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	"github.com/spf13/cobra"
+	k8sclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+var (
+	analyzeNamespace     string
+	analyzeNamespaces    []string
+	analyzeFilters       []string
+	analyzeOutput        string
+	analyzeFailThreshold string
+	analyzeWait          bool
+	analyzeWaitTimeout   time.Duration
+	analyzeEventsFile    string
+)
+
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze",
+	Short: "Run the core analyzers against your cluster and report the results",
+	RunE:  runAnalyze,
+}
+
+func init() {
+	analyzeCmd.Flags().StringVarP(&analyzeNamespace, "namespace", "n", "", "namespace to analyze")
+	analyzeCmd.Flags().StringSliceVar(&analyzeNamespaces, "namespaces", nil, "analyze every listed namespace instead of just --namespace, aggregating results (useful with least-privilege service accounts); --wait polls only --namespace")
+	analyzeCmd.Flags().StringSliceVarP(&analyzeFilters, "filter", "f", nil, "only run these analyzers (defaults to active_filters, then all; --wait ignores active_filters)")
+	analyzeCmd.Flags().StringVarP(&analyzeOutput, "output", "o", "text", "output format: json, text or score")
+	analyzeCmd.Flags().StringVar(&analyzeFailThreshold, "fail-threshold", "", "exit non-zero if the cluster grade is worse than this letter grade (e.g. B), for CI usage")
+	analyzeCmd.Flags().BoolVar(&analyzeWait, "wait", false, "poll the selected kinds for readiness instead of running a one-shot analysis (Helm-style)")
+	analyzeCmd.Flags().DurationVar(&analyzeWaitTimeout, "wait-timeout", 5*time.Minute, "how long --wait polls before reporting still-pending objects as NotReady")
+	analyzeCmd.Flags().StringVar(&analyzeEventsFile, "events-file", "", "append a JSONL stream of structured diagnostic events to this file, alongside the default verbose text output")
+	rootCmd.AddCommand(analyzeCmd)
+}
+
+func runAnalyze(cmd *cobra.Command, args []string) error {
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		clientcmd.NewDefaultClientConfigLoadingRules(),
+		&clientcmd.ConfigOverrides{},
+	).ClientConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	clientset, err := k8sclient.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	a := &analysis.Analysis{
+		Context:    context.Background(),
+		Filters:    analyzeFilters,
+		Client:     &kubernetes.Client{Client: clientset, RestConfig: restConfig},
+		Namespace:  analyzeNamespace,
+		Namespaces: analyzeNamespaces,
+	}
+
+	if analyzeEventsFile != "" {
+		f, err := os.OpenFile(analyzeEventsFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to open events file: %w", err)
+		}
+		defer f.Close()
+		a.EventSink = analysis.MultiEventSink{Sinks: []analysis.EventSink{analysis.DefaultEventSink(), analysis.NewJSONLEventSink(f)}}
+	}
+
+	if analyzeWait {
+		a.RunWaitAnalysis(analyzeWaitTimeout)
+	} else {
+		a.RunAnalysis()
+	}
+
+	out, err := a.PrintOutput(analyzeOutput)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), string(out))
+
+	if a.ExceedsFailThreshold(analyzeFailThreshold) {
+		os.Exit(1)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2023 The K8sGPT Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ai
+
+import (
+	"context"
+	"fmt"
+)
+
+// IAIConfig carries the provider-specific configuration handed to Configure.
+type IAIConfig interface {
+	GetPassword() string
+}
+
+// IAI is implemented by every supported AI backend.
+type IAI interface {
+	Configure(config IAIConfig) error
+	GetCompletion(ctx context.Context, prompt string) (string, error)
+	GetName() string
+}
+
+// NoOpAIClient is used in tests and in offline mode; it never calls out to a
+// real backend and simply echoes the prompt it was given.
+type NoOpAIClient struct{}
+
+func (c *NoOpAIClient) Configure(_ IAIConfig) error {
+	return nil
+}
+
+func (c *NoOpAIClient) GetCompletion(_ context.Context, prompt string) (string, error) {
+	return fmt.Sprintf("I am a noop response to the prompt %s", prompt), nil
+}
+
+func (c *NoOpAIClient) GetName() string {
+	return "noop"
+}
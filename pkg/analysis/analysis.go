@@ -0,0 +1,485 @@
+/*
+Copyright 2023 The K8sGPT Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/ai"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/analyzer"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/cache"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/common"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	"github.com/spf13/viper"
+)
+
+const (
+	StateOK              = "OK"
+	StateProblemDetected = "ProblemDetected"
+)
+
+// analyzerMap lists every core (built-in) analyzer keyed by the Kind a user
+// passes via --filter or active_filters.
+var analyzerMap = map[string]common.IAnalyzer{
+	"Pod":     analyzer.PodAnalyzer{},
+	"Service": analyzer.ServiceAnalyzer{},
+	"Ingress": analyzer.IngressAnalyzer{},
+}
+
+// Analysis is the top-level orchestrator: it runs the selected analyzers,
+// optionally asks the configured AI backend to explain the failures found,
+// and renders the result in the format the caller asked for.
+type Analysis struct {
+	Context   context.Context
+	Filters   []string
+	Client    *kubernetes.Client
+	Language  string
+	AIClient  ai.IAI
+	Results   []common.Result
+	Errors    []string
+	Namespace string
+	// Namespaces, when set, is iterated by RunAnalysis instead of Namespace
+	// so a single run can cover every namespace a least-privilege service
+	// account can actually see. RunWaitAnalysis polls Namespace only.
+	Namespaces     []string
+	Cache          cache.ICache
+	Explain        bool
+	MaxConcurrency int
+	WithDoc        bool
+
+	// Score/Grade/KindScores are populated by RunAnalysis once results are
+	// in, giving CI pipelines a single pass/fail number instead of having
+	// to parse the full result list.
+	Score      int
+	Grade      string
+	KindScores map[string]int
+
+	// Skipped records every (Kind, Namespace) pair an analyzer could not
+	// list/get because of an RBAC error, instead of failing the whole run.
+	Skipped []SkippedAnalyzer
+
+	// EventSink receives a structured Event for every analyzer launch,
+	// completion, skip and AI call. Defaults to stdout, reproducing the
+	// historical `Debug: ...` lines.
+	EventSink EventSink
+}
+
+// SkippedAnalyzer is recorded whenever an analyzer is skipped for a Kind in
+// a namespace because the caller lacks permission there.
+type SkippedAnalyzer struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Reason    string `json:"reason"`
+}
+
+// JsonOutput is the shape of `k8sgpt analyze -o json`.
+type JsonOutput struct {
+	Status   string            `json:"status"`
+	Problems int               `json:"problems"`
+	Results  []common.Result   `json:"results"`
+	Score    int               `json:"score"`
+	Grade    string            `json:"grade"`
+	Skipped  []SkippedAnalyzer `json:"skipped,omitempty"`
+}
+
+// RunAnalysis runs every analyzer selected by --filter (a.Filters), falling
+// back to the active_filters config value, and finally to every core
+// analyzer when neither is set.
+func (a *Analysis) RunAnalysis() {
+	activeFilters := viper.GetStringSlice("active_filters")
+
+	var filtersToRun []string
+	switch {
+	case len(a.Filters) > 0:
+		filtersToRun = a.Filters
+		a.emit(Event{
+			Phase:    "Analyze",
+			Category: CategoryFilterSelected,
+			Message:  fmt.Sprintf("Filter flags %v specified, run selected core analyzers.", filtersToRun),
+		})
+	case len(activeFilters) > 0:
+		filtersToRun = activeFilters
+		a.emit(Event{
+			Phase:    "Analyze",
+			Category: CategoryFilterSelected,
+			Message:  fmt.Sprintf("Found active filters %v, run selected core analyzers.", filtersToRun),
+		})
+	default:
+		for name := range analyzerMap {
+			filtersToRun = append(filtersToRun, name)
+		}
+	}
+
+	namespaces := a.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{a.Namespace}
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	maxConcurrency := a.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = 1
+	}
+	semaphore := make(chan struct{}, maxConcurrency)
+
+	for _, namespace := range namespaces {
+		for _, name := range filtersToRun {
+			az, ok := analyzerMap[name]
+			if !ok {
+				continue
+			}
+
+			wg.Add(1)
+			semaphore <- struct{}{}
+			go func(namespace, name string, az common.IAnalyzer) {
+				defer wg.Done()
+				defer func() { <-semaphore }()
+
+				a.emit(Event{
+					Phase:     "Analyze",
+					Analyzer:  name,
+					Kind:      name,
+					Namespace: namespace,
+					Category:  CategoryAnalyzerLaunched,
+					Message:   fmt.Sprintf("%sAnalyzer launched.", name),
+				})
+
+				results, err := az.Analyze(common.Analyzer{
+					Client:    a.Client,
+					Context:   a.Context,
+					Namespace: namespace,
+					AIClient:  a.AIClient,
+				})
+
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					var forbidden *common.ForbiddenError
+					if errors.As(err, &forbidden) {
+						a.Skipped = append(a.Skipped, SkippedAnalyzer{
+							Kind:      forbidden.Kind,
+							Namespace: forbidden.Namespace,
+							Reason:    forbidden.Reason,
+						})
+						a.emit(Event{
+							Phase:     "Analyze",
+							Analyzer:  name,
+							Kind:      name,
+							Namespace: namespace,
+							Category:  CategoryAnalyzerSkipped,
+							Message:   fmt.Sprintf("%sAnalyzer skipped in namespace %s: %s", name, namespace, forbidden.Reason),
+						})
+						return
+					}
+					a.Errors = append(a.Errors, fmt.Sprintf("[%s] %s", name, err))
+					a.emit(Event{
+						Phase:     "Analyze",
+						Analyzer:  name,
+						Kind:      name,
+						Namespace: namespace,
+						Category:  CategoryAnalyzerError,
+						Message:   fmt.Sprintf("%sAnalyzer failed: %s", name, err),
+					})
+					return
+				}
+				a.emit(Event{
+					Phase:     "Analyze",
+					Analyzer:  name,
+					Kind:      name,
+					Namespace: namespace,
+					Category:  CategoryAnalyzerCompleted,
+					Message:   fmt.Sprintf("%sAnalyzer completed without errors.", name),
+				})
+				a.Results = append(a.Results, results...)
+			}(namespace, name, az)
+		}
+	}
+	wg.Wait()
+
+	a.Score, a.Grade, a.KindScores = a.computeScore()
+}
+
+// RunCustomAnalysis reads the custom_analyzers config key and emits a
+// CustomAnalyzersNotFound event when it is unset or empty. It does not
+// dispatch the analyzers declared there and adds no Results.
+func (a *Analysis) RunCustomAnalysis() {
+	raw, ok := viper.Get("custom_analyzers").([]interface{})
+	if !ok || len(raw) == 0 {
+		a.emit(Event{
+			Phase:    "CustomAnalyze",
+			Category: CategoryCustomAnalyzers,
+			Message:  "No custom analyzers found.",
+		})
+		return
+	}
+	// Declared custom analyzers are not run here.
+}
+
+// severityWeight maps a Failure's severity to the score deduction it causes.
+// Failures created before severity existed default to SeverityError so they
+// keep affecting the score the way they always have.
+func severityWeight(level common.SeverityLevel) int {
+	switch level {
+	case common.SeverityInfo:
+		return 1
+	case common.SeverityWarn:
+		return 3
+	case common.SeverityCritical:
+		return 10
+	case common.SeverityError:
+		return 5
+	default:
+		return 5
+	}
+}
+
+// kindWeight lets operators tune how much a given Kind's failures count
+// against the cluster score, e.g. score.weights.Pod=2.0 in the config file.
+func kindWeight(kind string) float64 {
+	key := fmt.Sprintf("score.weights.%s", kind)
+	if viper.IsSet(key) {
+		return viper.GetFloat64(key)
+	}
+	return 1.0
+}
+
+// gradeForScore converts a 0-100 score into a Popeye-style letter grade.
+func gradeForScore(score int) string {
+	switch {
+	case score >= 90:
+		return "A"
+	case score >= 80:
+		return "B"
+	case score >= 70:
+		return "C"
+	case score >= 60:
+		return "D"
+	default:
+		return "F"
+	}
+}
+
+// gradeRank lets grades be compared for the --fail-threshold check; lower is
+// worse.
+func gradeRank(grade string) int {
+	switch grade {
+	case "A":
+		return 4
+	case "B":
+		return 3
+	case "C":
+		return 2
+	case "D":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// computeScore derives a 0-100 score per Kind (starting at 100 and
+// subtracting weighted deductions for every failure) and an overall grade
+// that is the average of those sub-scores.
+func (a *Analysis) computeScore() (int, string, map[string]int) {
+	kindScores := map[string]int{}
+
+	for _, result := range a.Results {
+		deduction := 0
+		for _, failure := range result.Error {
+			deduction += severityWeight(failure.Severity)
+		}
+		deduction = int(float64(deduction) * kindWeight(result.Kind))
+
+		score, ok := kindScores[result.Kind]
+		if !ok {
+			score = 100
+		}
+		score -= deduction
+		if score < 0 {
+			score = 0
+		}
+		kindScores[result.Kind] = score
+	}
+
+	if len(kindScores) == 0 {
+		return 100, "A", kindScores
+	}
+
+	total := 0
+	for _, score := range kindScores {
+		total += score
+	}
+	overall := total / len(kindScores)
+	return overall, gradeForScore(overall), kindScores
+}
+
+// ExceedsFailThreshold reports whether the cluster grade is worse than
+// threshold, e.g. ExceedsFailThreshold("B") is true for a C/D/F grade. It is
+// used by the CLI to pick a non-zero exit code for CI pipelines.
+func (a *Analysis) ExceedsFailThreshold(threshold string) bool {
+	if threshold == "" {
+		return false
+	}
+	return gradeRank(a.Grade) < gradeRank(threshold)
+}
+
+// PrintOutput renders the analysis in the requested format: "json", "text"
+// or the new Popeye-style "score" report.
+func (a *Analysis) PrintOutput(format string) ([]byte, error) {
+	problems := 0
+	for _, result := range a.Results {
+		problems += len(result.Error)
+	}
+
+	status := StateOK
+	if problems > 0 {
+		status = StateProblemDetected
+	}
+
+	switch format {
+	case "json":
+		output := JsonOutput{
+			Status:   status,
+			Problems: problems,
+			Results:  a.Results,
+			Score:    a.Score,
+			Grade:    a.Grade,
+			Skipped:  a.Skipped,
+		}
+		return json.MarshalIndent(output, "", "  ")
+	case "score":
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "Cluster Score: %d (%s)\n", a.Score, a.Grade)
+
+		kinds := make([]string, 0, len(a.KindScores))
+		for kind := range a.KindScores {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+		for _, kind := range kinds {
+			fmt.Fprintf(&sb, "  %s: %d\n", kind, a.KindScores[kind])
+		}
+		return []byte(sb.String()), nil
+	case "text":
+		var sb strings.Builder
+		if problems == 0 {
+			fmt.Fprintln(&sb, "No problems detected")
+		}
+		for i, result := range a.Results {
+			fmt.Fprintf(&sb, "%d %s(%s)\n", i, result.Name, result.ParentObject)
+			for _, failure := range result.Error {
+				fmt.Fprintf(&sb, "- Error: %s\n", failure.Text)
+			}
+			if result.Details != "" {
+				fmt.Fprintln(&sb, result.Details)
+			}
+		}
+		return []byte(sb.String()), nil
+	default:
+		return nil, fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
+// GetAIResults asks the configured AI backend to explain every Failure
+// found, populating Result.Details. It is a no-op when no AIClient is set.
+func (a *Analysis) GetAIResults(output string, anonymize bool) error {
+	if a.AIClient == nil {
+		return nil
+	}
+
+	a.emit(Event{
+		Phase:    "GetAIResults",
+		Category: CategoryAIRequest,
+		Message:  "Generating AI analysis.",
+	})
+
+	for i, result := range a.Results {
+		if len(result.Error) == 0 {
+			continue
+		}
+
+		var texts []string
+		for _, failure := range result.Error {
+			if anonymize {
+				masked := failure.Text
+				for _, s := range failure.Sensitive {
+					masked = strings.ReplaceAll(masked, s.Unmasked, s.Masked)
+				}
+				texts = append(texts, masked)
+			} else {
+				texts = append(texts, failure.Text)
+			}
+		}
+
+		response, err := a.getAIResultForSanitizedFailures(texts, "%s %s")
+		if err != nil {
+			return err
+		}
+
+		if anonymize {
+			for _, failure := range result.Error {
+				for _, s := range failure.Sensitive {
+					response = strings.ReplaceAll(response, s.Masked, s.Unmasked)
+				}
+			}
+		}
+
+		a.Results[i].Details = response
+	}
+
+	_ = output // reserved for format-specific prompt templates
+	return nil
+}
+
+// getAIResultForSanitizedFailures formats the given failures into a prompt,
+// serving a cached response when available and storing the fresh one
+// otherwise.
+func (a *Analysis) getAIResultForSanitizedFailures(texts []string, promptTmpl string) (string, error) {
+	inputKey := strings.Join(texts, " ")
+	cacheKey := fmt.Sprintf("%s_%s_%s", a.AIClient.GetName(), a.Language, inputKey)
+
+	if a.Cache != nil && !a.Cache.IsCacheDisabled() {
+		if cached, err := a.Cache.Load(cacheKey); err == nil {
+			decoded, err := base64.StdEncoding.DecodeString(cached)
+			if err == nil {
+				a.emit(Event{
+					Phase:    "GetAIResults",
+					Analyzer: a.AIClient.GetName(),
+					Category: CategoryAIResponseCached,
+					Message:  "Using cached AI response.",
+				})
+				return string(decoded), nil
+			}
+		}
+	}
+
+	prompt := fmt.Sprintf(promptTmpl, a.Language, inputKey)
+	response, err := a.AIClient.GetCompletion(a.Context, prompt)
+	if err != nil {
+		return "", err
+	}
+
+	if a.Cache != nil {
+		_ = a.Cache.Store(cacheKey, base64.StdEncoding.EncodeToString([]byte(response)))
+	}
+
+	return response, nil
+}
@@ -21,6 +21,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/k8sgpt-ai/k8sgpt/pkg/ai"
 	"github.com/k8sgpt-ai/k8sgpt/pkg/cache"
@@ -31,8 +32,12 @@ import (
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
 )
 
 // helper function to capture stdout
@@ -55,7 +60,7 @@ func contains(s, substr string) bool {
 }
 
 // sub-function
-func analysis_RunAnalysisFilterTester(t *testing.T, filterFlag string) []common.Result {
+func analysis_RunAnalysisFilterTester(t *testing.T, filterFlag string, sink ...EventSink) []common.Result {
 	clientset := fake.NewSimpleClientset(
 		&v1.Pod{
 			ObjectMeta: metav1.ObjectMeta{
@@ -111,6 +116,9 @@ func analysis_RunAnalysisFilterTester(t *testing.T, filterFlag string) []common.
 		},
 		WithDoc: true,
 	}
+	if len(sink) > 0 {
+		analysis.EventSink = sink[0]
+	}
 	if len(filterFlag) > 0 {
 		// `--filter` is explicitly given
 		analysis.Filters = strings.Split(filterFlag, ",")
@@ -429,9 +437,10 @@ func TestGetAIResultForSanitizedFailures(t *testing.T) {
 // Test: Verbose output in RunAnalysis with filter flag
 func TestVerbose_RunAnalysisWithFilter(t *testing.T) {
 	viper.Set("verbose", true)
+	sink := &InMemoryEventSink{}
 	// Run analysis with a filter flag ("Pod") to trigger debug output.
 	output := captureOutput(func() {
-		_ = analysis_RunAnalysisFilterTester(t, "Pod")
+		_ = analysis_RunAnalysisFilterTester(t, "Pod", MultiEventSink{Sinks: []EventSink{stdoutEventSink{}, sink}})
 	})
 	if !contains(output, "Debug: Filter flags [Pod] specified, run selected core analyzers.") {
 		t.Errorf("Expected debug output indicating filter flags [Pod] specified, but got: %s", output)
@@ -442,14 +451,23 @@ func TestVerbose_RunAnalysisWithFilter(t *testing.T) {
 	if !contains(output, "Debug: PodAnalyzer completed without errors.") {
 		t.Errorf("Expected debug output indicating PodAnalyzer completion without errors, but got: %s", output)
 	}
+
+	categories := map[EventCategory]bool{}
+	for _, e := range sink.Events {
+		categories[e.Category] = true
+	}
+	require.True(t, categories[CategoryFilterSelected])
+	require.True(t, categories[CategoryAnalyzerLaunched])
+	require.True(t, categories[CategoryAnalyzerCompleted])
 }
 
 // Test: Verbose output in RunAnalysis with active filter
 func TestVerbose_RunAnalysisActiveFilter(t *testing.T) {
 	viper.Set("verbose", true)
 	viper.SetDefault("active_filters", "Ingress")
+	sink := &InMemoryEventSink{}
 	output := captureOutput(func() {
-		_ = analysis_RunAnalysisFilterTester(t, "")
+		_ = analysis_RunAnalysisFilterTester(t, "", MultiEventSink{Sinks: []EventSink{stdoutEventSink{}, sink}})
 	})
 	if !contains(output, "Debug: Found active filters [Ingress], run selected core analyzers.") {
 		t.Errorf("Expected debug output indicating active filters [Ingress] found, but got: %s", output)
@@ -460,6 +478,18 @@ func TestVerbose_RunAnalysisActiveFilter(t *testing.T) {
 	if !contains(output, "Debug: IngressAnalyzer completed without errors.") {
 		t.Errorf("Expected debug output indicating IngressAnalyzer completion without errors, but got: %s", output)
 	}
+
+	foundLaunch, foundCompleted := false, false
+	for _, e := range sink.Events {
+		if e.Category == CategoryAnalyzerLaunched && e.Kind == "Ingress" {
+			foundLaunch = true
+		}
+		if e.Category == CategoryAnalyzerCompleted && e.Kind == "Ingress" {
+			foundCompleted = true
+		}
+	}
+	require.True(t, foundLaunch)
+	require.True(t, foundCompleted)
 }
 
 // Test: Verbose output in GetAIResults
@@ -468,9 +498,11 @@ func TestVerbose_GetAIResults(t *testing.T) {
 	disabledCache := cache.New("disabled-cache")
 	disabledCache.DisableCache()
 	aiClient := &ai.NoOpAIClient{}
+	sink := &InMemoryEventSink{}
 	analysisObj := Analysis{
-		AIClient: aiClient,
-		Cache:    disabledCache,
+		AIClient:  aiClient,
+		Cache:     disabledCache,
+		EventSink: MultiEventSink{Sinks: []EventSink{stdoutEventSink{}, sink}},
 		Results: []common.Result{
 			{
 				Kind:         "Deployment",
@@ -488,6 +520,189 @@ func TestVerbose_GetAIResults(t *testing.T) {
 	if !contains(output, "Debug: Generating AI analysis.") {
 		t.Errorf("Expected debug output indicating AI analysis generation, but got: %s", output)
 	}
+
+	foundRequest := false
+	for _, e := range sink.Events {
+		if e.Category == CategoryAIRequest {
+			foundRequest = true
+		}
+	}
+	require.True(t, foundRequest)
+}
+
+// Test: a Forbidden error on one Kind is recorded as Skipped instead of failing the run
+func TestAnalysis_RunAnalysisSkipsForbiddenKind(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		},
+		&v1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		},
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+			Spec:       v1.ServiceSpec{Selector: map[string]string{"app": "example"}},
+		},
+	)
+	clientset.PrependReactor("list", "pods", func(action ktesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", fmt.Errorf("not allowed"))
+	})
+
+	analysis := Analysis{
+		Context:        context.Background(),
+		Results:        []common.Result{},
+		Namespace:      "default",
+		MaxConcurrency: 1,
+		Filters:        []string{"Pod", "Service"},
+		Client: &kubernetes.Client{
+			Client: clientset,
+		},
+	}
+	analysis.RunAnalysis()
+
+	require.Empty(t, analysis.Errors)
+	require.Len(t, analysis.Skipped, 1)
+	assert.Equal(t, analysis.Skipped[0].Kind, "Pod")
+	assert.Equal(t, analysis.Skipped[0].Namespace, "default")
+}
+
+// Test: Namespaces is iterated so a Forbidden Kind is recorded once per
+// (namespace, kind) pair, and non-forbidden results are aggregated across
+// every namespace.
+func TestAnalysis_RunAnalysisSkipsForbiddenKindAcrossNamespaces(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "ns-a"}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "ns-b"}},
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "ns-a"},
+			Spec:       v1.ServiceSpec{Selector: map[string]string{"app": "example"}},
+		},
+		&v1.Endpoints{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "ns-a"}},
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "ns-b"},
+			Spec:       v1.ServiceSpec{Selector: map[string]string{"app": "example"}},
+		},
+		&v1.Endpoints{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "ns-b"}},
+	)
+	clientset.PrependReactor("list", "pods", func(action ktesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", fmt.Errorf("not allowed"))
+	})
+
+	analysis := Analysis{
+		Context:        context.Background(),
+		Results:        []common.Result{},
+		Namespaces:     []string{"ns-a", "ns-b"},
+		MaxConcurrency: 1,
+		Filters:        []string{"Pod", "Service"},
+		Client: &kubernetes.Client{
+			Client: clientset,
+		},
+	}
+	analysis.RunAnalysis()
+
+	require.Empty(t, analysis.Errors)
+	require.Len(t, analysis.Skipped, 2)
+
+	skippedNamespaces := map[string]bool{}
+	for _, s := range analysis.Skipped {
+		assert.Equal(t, s.Kind, "Pod")
+		skippedNamespaces[s.Namespace] = true
+	}
+	require.True(t, skippedNamespaces["ns-a"])
+	require.True(t, skippedNamespaces["ns-b"])
+
+	require.Len(t, analysis.Results, 2)
+	resultNamespaces := map[string]bool{}
+	for _, r := range analysis.Results {
+		assert.Equal(t, r.Kind, "Service")
+		resultNamespaces[r.Name] = true
+	}
+	require.True(t, resultNamespaces["ns-a/example"])
+	require.True(t, resultNamespaces["ns-b/example"])
+}
+
+// Test: RunWaitAnalysis reports a pod that never reaches Running within the timeout
+func TestAnalysis_RunWaitAnalysisPodStuckPending(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "stuck-pod",
+				Namespace: "default",
+			},
+			Status: v1.PodStatus{
+				Phase: v1.PodPending,
+			},
+		},
+	)
+
+	analysis := Analysis{
+		Context:   context.Background(),
+		Results:   []common.Result{},
+		Namespace: "default",
+		Filters:   []string{"Pod"},
+		Client: &kubernetes.Client{
+			Client: clientset,
+		},
+	}
+
+	analysis.RunWaitAnalysis(10 * time.Millisecond)
+
+	require.Len(t, analysis.Results, 1)
+	assert.Equal(t, analysis.Results[0].Kind, "Pod")
+	require.Contains(t, analysis.Results[0].Error[0].Text, "NotReady")
+}
+
+// Test: a cluster with no problems scores 100 and grades A
+func TestAnalysis_ScoreNoProblems(t *testing.T) {
+	analysis := Analysis{Results: []common.Result{}}
+	analysis.Score, analysis.Grade, analysis.KindScores = analysis.computeScore()
+
+	require.Equal(t, 100, analysis.Score)
+	require.Equal(t, "A", analysis.Grade)
+}
+
+// Test: mixed-severity results produce the expected weighted score
+func TestAnalysis_ScoreMixedSeverity(t *testing.T) {
+	analysis := Analysis{
+		Results: []common.Result{
+			{
+				Kind: "Pod",
+				Name: "crashloop-pod",
+				Error: []common.Failure{
+					{Text: "pod is crashing", Severity: common.SeverityCritical},
+					{Text: "pod is unscheduled", Severity: common.SeverityWarn},
+				},
+			},
+		},
+	}
+	analysis.Score, analysis.Grade, analysis.KindScores = analysis.computeScore()
+
+	// 100 - (weight(critical)=10 + weight(warn)=3) = 87
+	require.Equal(t, 87, analysis.KindScores["Pod"])
+	require.Equal(t, 87, analysis.Score)
+	require.Equal(t, "B", analysis.Grade)
+}
+
+// Test: ExceedsFailThreshold flags a cluster grade worse than the configured floor
+func TestAnalysis_ExceedsFailThreshold(t *testing.T) {
+	analysis := Analysis{
+		Results: []common.Result{
+			{
+				Kind: "Pod",
+				Name: "crashloop-pod",
+				Error: []common.Failure{
+					{Text: "pod is crashing", Severity: common.SeverityCritical},
+					{Text: "pod is crashing again", Severity: common.SeverityCritical},
+					{Text: "pod is crashing yet again", Severity: common.SeverityCritical},
+				},
+			},
+		},
+	}
+	analysis.Score, analysis.Grade, analysis.KindScores = analysis.computeScore()
+
+	require.True(t, analysis.ExceedsFailThreshold("B"))
+	require.False(t, analysis.ExceedsFailThreshold("F"))
+	require.False(t, analysis.ExceedsFailThreshold(""))
 }
 
 // Test: Verbose output in RunCustomAnalysis
@@ -495,8 +710,11 @@ func TestVerbose_RunCustomAnalysis(t *testing.T) {
 	viper.Set("verbose", true)
 	// Set custom_analyzers to empty array to trigger "No custom analyzers" debug message.
 	viper.Set("custom_analyzers", []interface{}{})
+	sink := &InMemoryEventSink{}
+	var jsonl bytes.Buffer
 	analysisObj := &Analysis{
 		MaxConcurrency: 1,
+		EventSink:      MultiEventSink{Sinks: []EventSink{stdoutEventSink{}, sink, NewJSONLEventSink(&jsonl)}},
 	}
 	output := captureOutput(func() {
 		analysisObj.RunCustomAnalysis()
@@ -504,4 +722,46 @@ func TestVerbose_RunCustomAnalysis(t *testing.T) {
 	if !contains(output, "Debug: No custom analyzers found.") {
 		t.Errorf("Expected debug output indicating no custom analyzers found, but got: %s", output)
 	}
+
+	foundCategory := false
+	for _, e := range sink.Events {
+		if e.Category == CategoryCustomAnalyzers {
+			foundCategory = true
+		}
+	}
+	require.True(t, foundCategory)
+
+	line := strings.TrimSpace(jsonl.String())
+	require.NotEmpty(t, line)
+	var got Event
+	require.NoError(t, json.Unmarshal([]byte(line), &got))
+	require.Equal(t, CategoryCustomAnalyzers, got.Category)
+	require.Equal(t, "No custom analyzers found.", got.Message)
+}
+
+// Test: JSONLEventSink marshals every field of an Event onto its own line
+func TestJSONLEventSink_Emit(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewJSONLEventSink(&buf)
+
+	sink.Emit(Event{
+		Phase:     "Analyze",
+		Analyzer:  "Pod",
+		Kind:      "Pod",
+		Namespace: "default",
+		Category:  CategoryAnalyzerLaunched,
+		Message:   "PodAnalyzer launched.",
+		Severity:  common.SeverityWarn,
+	})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 1)
+
+	var got Event
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &got))
+	require.Equal(t, CategoryAnalyzerLaunched, got.Category)
+	require.Equal(t, "PodAnalyzer launched.", got.Message)
+	require.Equal(t, "Pod", got.Kind)
+	require.Equal(t, "default", got.Namespace)
+	require.Equal(t, common.SeverityWarn, got.Severity)
 }
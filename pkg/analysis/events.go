@@ -0,0 +1,139 @@
+/*
+Copyright 2023 The K8sGPT Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/common"
+	"github.com/spf13/viper"
+)
+
+// EventCategory is a stable identifier for what happened, so downstream
+// tooling (dashboards, CI parsers) can key off it instead of regex-scraping
+// stdout.
+type EventCategory string
+
+const (
+	CategoryFilterSelected    EventCategory = "FilterSelected"
+	CategoryAnalyzerLaunched  EventCategory = "AnalyzerLaunched"
+	CategoryAnalyzerCompleted EventCategory = "AnalyzerCompleted"
+	CategoryAnalyzerError     EventCategory = "AnalyzerError"
+	CategoryAnalyzerSkipped   EventCategory = "AnalyzerSkipped"
+	CategoryAIRequest         EventCategory = "AIRequest"
+	CategoryAIResponseCached  EventCategory = "AIResponseCached"
+	CategoryCustomAnalyzers   EventCategory = "CustomAnalyzersNotFound"
+)
+
+// Event is a single structured diagnostic emitted during RunAnalysis,
+// RunCustomAnalysis or GetAIResults.
+type Event struct {
+	Timestamp time.Time            `json:"timestamp"`
+	Phase     string               `json:"phase"`
+	Analyzer  string               `json:"analyzer,omitempty"`
+	Kind      string               `json:"kind,omitempty"`
+	Namespace string               `json:"namespace,omitempty"`
+	Object    string               `json:"object,omitempty"`
+	Category  EventCategory        `json:"category"`
+	Message   string               `json:"message"`
+	Severity  common.SeverityLevel `json:"severity,omitempty"`
+}
+
+// EventSink receives every Event emitted during a run.
+type EventSink interface {
+	Emit(e Event)
+}
+
+// JSONLEventSink writes one JSON object per line, suitable for the
+// --events-file flag or for piping into a log aggregator.
+type JSONLEventSink struct {
+	Writer io.Writer
+}
+
+func NewJSONLEventSink(w io.Writer) *JSONLEventSink {
+	return &JSONLEventSink{Writer: w}
+}
+
+func (s *JSONLEventSink) Emit(e Event) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(s.Writer, string(b))
+}
+
+// InMemoryEventSink buffers every event it receives; it is primarily useful
+// in tests that want to assert on the categorized event stream instead of
+// scraping captured stdout.
+type InMemoryEventSink struct {
+	mu     sync.Mutex
+	Events []Event
+}
+
+func (s *InMemoryEventSink) Emit(e Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Events = append(s.Events, e)
+}
+
+// MultiEventSink fans a single Event out to every sink it wraps, e.g. the
+// default text stream plus a JSONL file from --events-file.
+type MultiEventSink struct {
+	Sinks []EventSink
+}
+
+func (s MultiEventSink) Emit(e Event) {
+	for _, sink := range s.Sinks {
+		sink.Emit(e)
+	}
+}
+
+// stdoutEventSink is the zero-value default: it resolves os.Stdout on every
+// Emit rather than once at construction, which is what lets tests capture
+// it by swapping the global.
+type stdoutEventSink struct{}
+
+func (stdoutEventSink) Emit(e Event) {
+	if !viper.GetBool("verbose") {
+		return
+	}
+	fmt.Fprintf(os.Stdout, "Debug: %s\n", e.Message)
+}
+
+// DefaultEventSink returns the stdout sink RunAnalysis falls back to when
+// Analysis.EventSink is unset, so callers that want to add another sink
+// (e.g. a JSONL file from --events-file) can compose it into a
+// MultiEventSink instead of losing the verbose `Debug:` stream.
+func DefaultEventSink() EventSink {
+	return stdoutEventSink{}
+}
+
+// eventSink returns the configured EventSink, defaulting to stdout so
+// behavior matches the pre-event-stream `Debug:` printlns.
+func (a *Analysis) eventSink() EventSink {
+	if a.EventSink == nil {
+		return stdoutEventSink{}
+	}
+	return a.EventSink
+}
+
+func (a *Analysis) emit(e Event) {
+	e.Timestamp = time.Now()
+	a.eventSink().Emit(e)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2023 The K8sGPT Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/common"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/waitanalyzer"
+)
+
+// waitAnalyzerMap lists every Kind the wait analyzer knows how to poll for
+// readiness. It intentionally mirrors (rather than reuses) analyzerMap,
+// since a readiness predicate is a different question from "is this object
+// currently broken".
+var waitAnalyzerMap = map[string]waitanalyzer.IWaitAnalyzer{
+	"Pod":         waitanalyzer.PodWaitAnalyzer{},
+	"Deployment":  waitanalyzer.DeploymentWaitAnalyzer{},
+	"StatefulSet": waitanalyzer.StatefulSetWaitAnalyzer{},
+	"DaemonSet":   waitanalyzer.DaemonSetWaitAnalyzer{},
+	"Service":     waitanalyzer.ServiceWaitAnalyzer{},
+	"PVC":         waitanalyzer.PVCWaitAnalyzer{},
+	"Job":         waitanalyzer.JobWaitAnalyzer{},
+}
+
+// RunWaitAnalysis polls every selected Kind until each object it returns is
+// ready, or until timeout elapses. Objects still not ready at the deadline
+// are reported as ordinary common.Result entries (failure text "NotReady")
+// so the rest of the pipeline - including GetAIResults - doesn't need a
+// special case for them.
+func (a *Analysis) RunWaitAnalysis(timeout time.Duration) {
+	kinds := a.Filters
+	if len(kinds) == 0 {
+		for kind := range waitAnalyzerMap {
+			kinds = append(kinds, kind)
+		}
+	}
+
+	deadline := time.Now().Add(timeout)
+	const pollInterval = 2 * time.Second
+
+	pending := map[string]waitanalyzer.IWaitAnalyzer{}
+	for _, kind := range kinds {
+		if wa, ok := waitAnalyzerMap[kind]; ok {
+			pending[kind] = wa
+		}
+	}
+
+	for len(pending) > 0 {
+		for kind, wa := range pending {
+			notReady, err := wa.CheckReady(common.Analyzer{
+				Client:    a.Client,
+				Context:   a.Context,
+				Namespace: a.Namespace,
+			})
+			if err != nil {
+				a.Errors = append(a.Errors, fmt.Sprintf("[%s] %s", kind, err))
+				delete(pending, kind)
+				continue
+			}
+			if len(notReady) == 0 {
+				delete(pending, kind)
+			}
+		}
+
+		if len(pending) == 0 {
+			break
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			break
+		}
+		if remaining < pollInterval {
+			time.Sleep(remaining)
+		} else {
+			time.Sleep(pollInterval)
+		}
+	}
+
+	// Whatever is still pending at the deadline never reached a ready state
+	// in time; report the last known reason. Kinds that errored out above
+	// were already dropped from pending and recorded in a.Errors.
+	for _, wa := range pending {
+		notReady, err := wa.CheckReady(common.Analyzer{
+			Client:    a.Client,
+			Context:   a.Context,
+			Namespace: a.Namespace,
+		})
+		if err != nil {
+			continue
+		}
+		a.Results = append(a.Results, notReady...)
+	}
+
+	a.Score, a.Grade, a.KindScores = a.computeScore()
+}
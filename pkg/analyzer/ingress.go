@@ -0,0 +1,57 @@
+/*
+Copyright 2023 The K8sGPT Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analyzer
+
+import (
+	"fmt"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/common"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IngressAnalyzer flags ingresses with no rules and no default backend.
+type IngressAnalyzer struct{}
+
+func (IngressAnalyzer) Analyze(a common.Analyzer) ([]common.Result, error) {
+	kind := "Ingress"
+
+	ingresses, err := a.Client.Client.NetworkingV1().Ingresses(a.Namespace).List(a.Context, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err) {
+			return nil, &common.ForbiddenError{Kind: kind, Namespace: a.Namespace, Reason: err.Error()}
+		}
+		return nil, err
+	}
+
+	var results []common.Result
+	for _, ing := range ingresses.Items {
+		if ing.Spec.DefaultBackend == nil && len(ing.Spec.Rules) == 0 {
+			results = append(results, common.Result{
+				Kind: kind,
+				Name: fmt.Sprintf("%s/%s", ing.Namespace, ing.Name),
+				Error: []common.Failure{
+					{
+						Text:      fmt.Sprintf("Ingress %s has no rules or default backend configured", ing.Name),
+						Severity:  common.SeverityWarn,
+						Sensitive: []common.Sensitive{{Unmasked: ing.Name, Masked: ing.Name}},
+					},
+				},
+				ParentObject: ing.Name,
+			})
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2023 The K8sGPT Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analyzer
+
+import (
+	"fmt"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/common"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodAnalyzer flags pods that are not scheduled or have unready containers.
+type PodAnalyzer struct{}
+
+func (PodAnalyzer) Analyze(a common.Analyzer) ([]common.Result, error) {
+	kind := "Pod"
+
+	pods, err := a.Client.Client.CoreV1().Pods(a.Namespace).List(a.Context, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err) {
+			return nil, &common.ForbiddenError{Kind: kind, Namespace: a.Namespace, Reason: err.Error()}
+		}
+		return nil, err
+	}
+
+	var results []common.Result
+	for _, pod := range pods.Items {
+		var failures []common.Failure
+
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == v1.PodScheduled && cond.Status != v1.ConditionTrue {
+				failures = append(failures, common.Failure{
+					Text:      fmt.Sprintf("Pod %s is not scheduled: %s", pod.Name, cond.Message),
+					Severity:  common.SeverityCritical,
+					Sensitive: []common.Sensitive{{Unmasked: pod.Name, Masked: pod.Name}},
+				})
+			}
+		}
+
+		for _, cs := range pod.Status.ContainerStatuses {
+			if !cs.Ready && pod.Status.Phase == v1.PodRunning {
+				failures = append(failures, common.Failure{
+					Text:      fmt.Sprintf("Container %s in pod %s is not ready", cs.Name, pod.Name),
+					Severity:  common.SeverityError,
+					Sensitive: []common.Sensitive{{Unmasked: pod.Name, Masked: pod.Name}},
+				})
+			}
+		}
+
+		if len(failures) > 0 {
+			results = append(results, common.Result{
+				Kind:         kind,
+				Name:         fmt.Sprintf("%s/%s", pod.Namespace, pod.Name),
+				Error:        failures,
+				ParentObject: pod.Name,
+			})
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2023 The K8sGPT Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analyzer
+
+import (
+	"fmt"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/common"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServiceAnalyzer flags services that select pods but have no endpoints.
+type ServiceAnalyzer struct{}
+
+func (ServiceAnalyzer) Analyze(a common.Analyzer) ([]common.Result, error) {
+	kind := "Service"
+
+	services, err := a.Client.Client.CoreV1().Services(a.Namespace).List(a.Context, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err) {
+			return nil, &common.ForbiddenError{Kind: kind, Namespace: a.Namespace, Reason: err.Error()}
+		}
+		return nil, err
+	}
+
+	var results []common.Result
+	for _, svc := range services.Items {
+		if len(svc.Spec.Selector) == 0 {
+			continue
+		}
+
+		hasAddresses := false
+		endpoints, err := a.Client.Client.CoreV1().Endpoints(svc.Namespace).Get(a.Context, svc.Name, metav1.GetOptions{})
+		if err == nil {
+			for _, subset := range endpoints.Subsets {
+				if len(subset.Addresses) > 0 {
+					hasAddresses = true
+					break
+				}
+			}
+		}
+
+		if !hasAddresses {
+			results = append(results, common.Result{
+				Kind: kind,
+				Name: fmt.Sprintf("%s/%s", svc.Namespace, svc.Name),
+				Error: []common.Failure{
+					{
+						Text:      fmt.Sprintf("Service %s does not have any endpoints", svc.Name),
+						Severity:  common.SeverityWarn,
+						Sensitive: []common.Sensitive{{Unmasked: svc.Name, Masked: svc.Name}},
+					},
+				},
+				ParentObject: svc.Name,
+			})
+		}
+	}
+
+	return results, nil
+}
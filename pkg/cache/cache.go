@@ -0,0 +1,80 @@
+/*
+Copyright 2023 The K8sGPT Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import "fmt"
+
+// CacheProvider is a single cached AI response.
+type CacheProvider struct {
+	Key   string
+	Value string
+}
+
+// ICache is implemented by every cache backend.
+type ICache interface {
+	Store(key string, data string) error
+	Load(key string) (string, error)
+	List() ([]CacheProvider, error)
+	IsCacheDisabled() bool
+}
+
+// Cache is an in-memory cache keyed by provider/language/prompt. It is
+// primarily used in tests; production backends (filesystem, S3, ...) wrap
+// the same interface.
+type Cache struct {
+	name    string
+	noCache bool
+	data    map[string]string
+}
+
+// New creates a named cache instance.
+func New(name string) *Cache {
+	return &Cache{name: name, data: map[string]string{}}
+}
+
+// DisableCache turns this instance into a pass-through no-op cache.
+func (c *Cache) DisableCache() {
+	c.noCache = true
+}
+
+func (c *Cache) IsCacheDisabled() bool {
+	return c.noCache
+}
+
+func (c *Cache) Store(key string, data string) error {
+	if c.noCache {
+		return nil
+	}
+	c.data[key] = data
+	return nil
+}
+
+func (c *Cache) Load(key string) (string, error) {
+	if c.noCache {
+		return "", fmt.Errorf("cache disabled")
+	}
+	v, ok := c.data[key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in cache", key)
+	}
+	return v, nil
+}
+
+func (c *Cache) List() ([]CacheProvider, error) {
+	providers := make([]CacheProvider, 0, len(c.data))
+	for k, v := range c.data {
+		providers = append(providers, CacheProvider{Key: k, Value: v})
+	}
+	return providers, nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2023 The K8sGPT Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/ai"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+)
+
+// SeverityLevel classifies how serious a Failure is. It feeds directly into
+// the cluster scoring computed by the analysis package.
+type SeverityLevel string
+
+const (
+	SeverityInfo     SeverityLevel = "info"
+	SeverityWarn     SeverityLevel = "warn"
+	SeverityError    SeverityLevel = "error"
+	SeverityCritical SeverityLevel = "critical"
+)
+
+// Sensitive holds an unmasked/masked pair of strings so failure text can be
+// anonymized before being sent to an AI backend and restored afterwards.
+type Sensitive struct {
+	Unmasked string
+	Masked   string
+}
+
+// Failure is a single problem found on a Kubernetes object.
+type Failure struct {
+	Text          string
+	KubernetesDoc string
+	Sensitive     []Sensitive
+	// Severity defaults to SeverityError when left unset, so analyzers
+	// written before scoring existed keep behaving the same way.
+	Severity SeverityLevel `json:"severity,omitempty"`
+}
+
+// Result is everything found (and, eventually, explained) about one object.
+type Result struct {
+	Kind         string    `json:"kind"`
+	Name         string    `json:"name"`
+	Error        []Failure `json:"error"`
+	Details      string    `json:"details,omitempty"`
+	ParentObject string    `json:"parentObject,omitempty"`
+}
+
+// Analyzer is the shared context every built-in analyzer needs to talk to
+// the cluster and the configured AI backend.
+type Analyzer struct {
+	Client    *kubernetes.Client
+	Context   context.Context
+	Namespace string
+	AIClient  ai.IAI
+}
+
+// IAnalyzer is implemented by every built-in and custom analyzer registered
+// in the analyzer map.
+type IAnalyzer interface {
+	Analyze(analyzer Analyzer) ([]Result, error)
+}
+
+// ForbiddenError is returned by an analyzer instead of a raw API error when
+// a list/get call fails because the caller lacks permission on that Kind.
+// The analysis package treats it as a reason to skip the Kind in the
+// affected namespace rather than aborting the whole run.
+type ForbiddenError struct {
+	Kind      string
+	Namespace string
+	Reason    string
+}
+
+func (e *ForbiddenError) Error() string {
+	return fmt.Sprintf("%s/%s: %s", e.Kind, e.Namespace, e.Reason)
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2023 The K8sGPT Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package waitanalyzer
+
+import (
+	"fmt"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeploymentWaitAnalyzer mirrors `kubectl rollout status`: a deployment is
+// ready once its updated and available replica counts both match the
+// desired replica count and the controller has observed the latest spec.
+type DeploymentWaitAnalyzer struct{}
+
+func (DeploymentWaitAnalyzer) CheckReady(a common.Analyzer) ([]common.Result, error) {
+	deployments, err := a.Client.Client.AppsV1().Deployments(a.Namespace).List(a.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []common.Result
+	for _, dep := range deployments.Items {
+		desired := int32(1)
+		if dep.Spec.Replicas != nil {
+			desired = *dep.Spec.Replicas
+		}
+
+		ready := dep.Status.UpdatedReplicas == desired &&
+			dep.Status.AvailableReplicas == desired &&
+			dep.Status.ObservedGeneration >= dep.Generation
+
+		if !ready {
+			results = append(results, notReadyResult("Deployment", fmt.Sprintf("%s/%s", dep.Namespace, dep.Name),
+				fmt.Sprintf("%d/%d updated, %d/%d available replicas", dep.Status.UpdatedReplicas, desired, dep.Status.AvailableReplicas, desired)))
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2023 The K8sGPT Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package waitanalyzer
+
+import (
+	"fmt"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JobWaitAnalyzer considers a Job ready once it has completed as many pods
+// as it was asked to.
+type JobWaitAnalyzer struct{}
+
+func (JobWaitAnalyzer) CheckReady(a common.Analyzer) ([]common.Result, error) {
+	jobs, err := a.Client.Client.BatchV1().Jobs(a.Namespace).List(a.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []common.Result
+	for _, job := range jobs.Items {
+		completions := int32(1)
+		if job.Spec.Completions != nil {
+			completions = *job.Spec.Completions
+		}
+
+		if job.Status.Succeeded < completions {
+			results = append(results, notReadyResult("Job", fmt.Sprintf("%s/%s", job.Namespace, job.Name),
+				fmt.Sprintf("%d/%d completions succeeded", job.Status.Succeeded, completions)))
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2023 The K8sGPT Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package waitanalyzer
+
+import (
+	"fmt"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/common"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodWaitAnalyzer considers a pod ready once all its containers report
+// Ready=True and it is Running (or Succeeded, for restartPolicy Never).
+type PodWaitAnalyzer struct{}
+
+func (PodWaitAnalyzer) CheckReady(a common.Analyzer) ([]common.Result, error) {
+	pods, err := a.Client.Client.CoreV1().Pods(a.Namespace).List(a.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []common.Result
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == v1.PodSucceeded && pod.Spec.RestartPolicy == v1.RestartPolicyNever {
+			continue
+		}
+		if pod.Status.Phase != v1.PodRunning {
+			results = append(results, notReadyResult("Pod", fmt.Sprintf("%s/%s", pod.Namespace, pod.Name),
+				fmt.Sprintf("pod is in phase %s", pod.Status.Phase)))
+			continue
+		}
+
+		allReady := true
+		for _, cs := range pod.Status.ContainerStatuses {
+			if !cs.Ready {
+				allReady = false
+				break
+			}
+		}
+		if !allReady {
+			results = append(results, notReadyResult("Pod", fmt.Sprintf("%s/%s", pod.Namespace, pod.Name),
+				"not all containers are ready"))
+		}
+	}
+
+	return results, nil
+}
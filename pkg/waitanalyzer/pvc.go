@@ -0,0 +1,42 @@
+/*
+Copyright 2023 The K8sGPT Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package waitanalyzer
+
+import (
+	"fmt"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/common"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PVCWaitAnalyzer considers a PersistentVolumeClaim ready once it is Bound.
+type PVCWaitAnalyzer struct{}
+
+func (PVCWaitAnalyzer) CheckReady(a common.Analyzer) ([]common.Result, error) {
+	pvcs, err := a.Client.Client.CoreV1().PersistentVolumeClaims(a.Namespace).List(a.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []common.Result
+	for _, pvc := range pvcs.Items {
+		if pvc.Status.Phase != v1.ClaimBound {
+			results = append(results, notReadyResult("PVC", fmt.Sprintf("%s/%s", pvc.Namespace, pvc.Name),
+				fmt.Sprintf("claim is in phase %s", pvc.Status.Phase)))
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2023 The K8sGPT Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package waitanalyzer
+
+import (
+	"fmt"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/common"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServiceWaitAnalyzer considers a Service ready once it has at least one
+// endpoint address. ExternalName and headless-without-selector services
+// have nothing to wait for, so they are always ready.
+type ServiceWaitAnalyzer struct{}
+
+func (ServiceWaitAnalyzer) CheckReady(a common.Analyzer) ([]common.Result, error) {
+	services, err := a.Client.Client.CoreV1().Services(a.Namespace).List(a.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []common.Result
+	for _, svc := range services.Items {
+		if svc.Spec.Type == v1.ServiceTypeExternalName {
+			continue
+		}
+		if svc.Spec.ClusterIP == v1.ClusterIPNone && len(svc.Spec.Selector) == 0 {
+			continue
+		}
+
+		endpoints, err := a.Client.Client.CoreV1().Endpoints(svc.Namespace).Get(a.Context, svc.Name, metav1.GetOptions{})
+		hasAddresses := false
+		if err == nil {
+			for _, subset := range endpoints.Subsets {
+				if len(subset.Addresses) > 0 {
+					hasAddresses = true
+					break
+				}
+			}
+		}
+
+		if !hasAddresses {
+			results = append(results, notReadyResult("Service", fmt.Sprintf("%s/%s", svc.Namespace, svc.Name),
+				"service has no endpoint addresses"))
+		}
+	}
+
+	return results, nil
+}
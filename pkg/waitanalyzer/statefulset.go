@@ -0,0 +1,51 @@
+/*
+Copyright 2023 The K8sGPT Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package waitanalyzer
+
+import (
+	"fmt"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StatefulSetWaitAnalyzer considers a StatefulSet ready once every replica
+// has been updated to the current revision and is ready.
+type StatefulSetWaitAnalyzer struct{}
+
+func (StatefulSetWaitAnalyzer) CheckReady(a common.Analyzer) ([]common.Result, error) {
+	sets, err := a.Client.Client.AppsV1().StatefulSets(a.Namespace).List(a.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []common.Result
+	for _, sts := range sets.Items {
+		desired := int32(1)
+		if sts.Spec.Replicas != nil {
+			desired = *sts.Spec.Replicas
+		}
+
+		ready := sts.Status.UpdatedReplicas == desired &&
+			sts.Status.ReadyReplicas == desired &&
+			sts.Status.ObservedGeneration >= sts.Generation
+
+		if !ready {
+			results = append(results, notReadyResult("StatefulSet", fmt.Sprintf("%s/%s", sts.Namespace, sts.Name),
+				fmt.Sprintf("%d/%d updated, %d/%d ready replicas", sts.Status.UpdatedReplicas, desired, sts.Status.ReadyReplicas, desired)))
+		}
+	}
+
+	return results, nil
+}
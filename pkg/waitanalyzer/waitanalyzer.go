@@ -0,0 +1,39 @@
+/*
+Copyright 2023 The K8sGPT Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package waitanalyzer implements Helm-style "wait for ready" checks, as
+// opposed to the one-shot failure detection the analyzer package performs.
+package waitanalyzer
+
+import "github.com/k8sgpt-ai/k8sgpt/pkg/common"
+
+// IWaitAnalyzer is implemented by every Kind the wait mode knows how to
+// poll. CheckReady returns a common.Result (failure text "NotReady ...")
+// for every object of that Kind that has not yet reached a ready state.
+type IWaitAnalyzer interface {
+	CheckReady(a common.Analyzer) ([]common.Result, error)
+}
+
+func notReadyResult(kind, name, reason string) common.Result {
+	return common.Result{
+		Kind: kind,
+		Name: name,
+		Error: []common.Failure{
+			{
+				Text:     "NotReady: " + reason,
+				Severity: common.SeverityWarn,
+			},
+		},
+		ParentObject: name,
+	}
+}